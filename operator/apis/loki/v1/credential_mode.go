@@ -0,0 +1,25 @@
+package v1
+
+// CredentialMode represents the way in which credentials are provided
+// to the Loki components for authenticating with the object storage.
+//
+// +kubebuilder:validation:Enum=static;token;token-cco;service-principal
+type CredentialMode string
+
+const (
+	// CredentialModeStatic represents the usage of static, long-lived credentials stored in a Secret.
+	// This is the default authentication mode and available for all supported object storage types.
+	CredentialModeStatic CredentialMode = "static"
+	// CredentialModeToken represents the usage of short-lived tokens retrieved from a credential source.
+	// This mode is used by the AWS S3, Azure Blob Storage and GCS object storage integrations and relies on
+	// a well-known object storage secret name, conveying the same information as the associated static mode.
+	CredentialModeToken CredentialMode = "token"
+	// CredentialModeTokenCCO represents the usage of short-lived tokens retrieved from a credential source.
+	// This mode is similar to CredentialModeToken, but instead relies on the OpenShift cloud-credential-operator
+	// to provision the platform-specific credentials in a Kubernetes Secret that is mounted into the pod.
+	CredentialModeTokenCCO CredentialMode = "token-cco"
+	// CredentialModeAzureServicePrincipal represents the usage of a static Azure AD service principal
+	// (client ID, client secret, tenant ID) stored in the object storage Secret, as an alternative to
+	// static account-key credentials or Workload Identity federation.
+	CredentialModeAzureServicePrincipal CredentialMode = "service-principal"
+)
@@ -11,23 +11,21 @@ import (
 	"k8s.io/utils/ptr"
 
 	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+	"github.com/grafana/loki/operator/internal/naming"
 )
 
 // ConfigureDeployment appends additional pod volumes and container env vars, args, volume mounts
 // based on the object storage type. Currently supported amendments:
 // - All: Ensure object storage secret mounted and auth projected as env vars.
 // - GCS: Ensure env var GOOGLE_APPLICATION_CREDENTIALS in container
-// - S3: Ensure mounting custom CA configmap if any TLSConfig given
+// - All: Ensure mounting custom CA configmap if any TLSConfig given
 func ConfigureDeployment(d *appsv1.Deployment, opts Options) error {
 	switch opts.SharedStore {
-	case lokiv1.ObjectStorageSecretAlibabaCloud, lokiv1.ObjectStorageSecretAzure, lokiv1.ObjectStorageSecretGCS, lokiv1.ObjectStorageSecretSwift:
-		return configureDeployment(d, opts)
-	case lokiv1.ObjectStorageSecretS3:
-		err := configureDeployment(d, opts)
-		if err != nil {
+	case lokiv1.ObjectStorageSecretAlibabaCloud, lokiv1.ObjectStorageSecretAzure, lokiv1.ObjectStorageSecretGCS, lokiv1.ObjectStorageSecretS3, lokiv1.ObjectStorageSecretSwift:
+		if err := configureDeployment(d, opts); err != nil {
 			return err
 		}
-		return configureDeploymentCA(d, opts.TLS)
+		return configureDeploymentCA(d, opts)
 	default:
 		return nil
 	}
@@ -37,16 +35,14 @@ func ConfigureDeployment(d *appsv1.Deployment, opts Options) error {
 // based on the object storage type. Currently supported amendments:
 // - All: Ensure object storage secret mounted and auth projected as env vars.
 // - GCS: Ensure env var GOOGLE_APPLICATION_CREDENTIALS in container
-// - S3: Ensure mounting custom CA configmap if any TLSConfig given
+// - All: Ensure mounting custom CA configmap if any TLSConfig given
 func ConfigureStatefulSet(d *appsv1.StatefulSet, opts Options) error {
 	switch opts.SharedStore {
-	case lokiv1.ObjectStorageSecretAlibabaCloud, lokiv1.ObjectStorageSecretAzure, lokiv1.ObjectStorageSecretGCS, lokiv1.ObjectStorageSecretSwift:
-		return configureStatefulSet(d, opts)
-	case lokiv1.ObjectStorageSecretS3:
+	case lokiv1.ObjectStorageSecretAlibabaCloud, lokiv1.ObjectStorageSecretAzure, lokiv1.ObjectStorageSecretGCS, lokiv1.ObjectStorageSecretS3, lokiv1.ObjectStorageSecretSwift:
 		if err := configureStatefulSet(d, opts); err != nil {
 			return err
 		}
-		return configureStatefulSetCA(d, opts.TLS)
+		return configureStatefulSetCA(d, opts)
 	default:
 		return nil
 	}
@@ -63,18 +59,23 @@ func configureDeployment(d *appsv1.Deployment, opts Options) error {
 	return nil
 }
 
-// ConfigureDeploymentCA merges a S3 CA ConfigMap volume into the deployment spec.
-func configureDeploymentCA(d *appsv1.Deployment, tls *TLSConfig) error {
-	if tls == nil {
+// ConfigureDeploymentCA merges the object storage CA ConfigMap volume into the deployment spec.
+func configureDeploymentCA(d *appsv1.Deployment, opts Options) error {
+	if opts.TLS == nil {
 		return nil
 	}
 
-	p := ensureCAForS3(&d.Spec.Template.Spec, tls)
+	p := ensureCAForObjectStorage(&d.Spec.Template.Spec, opts)
 
 	if err := mergo.Merge(&d.Spec.Template.Spec, p, mergo.WithOverride); err != nil {
-		return kverrors.Wrap(err, "failed to merge s3 object storage ca options ")
+		return kverrors.Wrap(err, "failed to merge object storage ca options ")
 	}
 
+	if d.Spec.Template.Annotations == nil {
+		d.Spec.Template.Annotations = map[string]string{}
+	}
+	d.Spec.Template.Annotations[caHashAnnotation] = CAHashAnnotationSource(opts.TLS)
+
 	return nil
 }
 
@@ -89,18 +90,23 @@ func configureStatefulSet(s *appsv1.StatefulSet, opts Options) error {
 	return nil
 }
 
-// ConfigureStatefulSetCA merges a S3 CA ConfigMap volume into the statefulset spec.
-func configureStatefulSetCA(s *appsv1.StatefulSet, tls *TLSConfig) error {
-	if tls == nil {
+// ConfigureStatefulSetCA merges the object storage CA ConfigMap volume into the statefulset spec.
+func configureStatefulSetCA(s *appsv1.StatefulSet, opts Options) error {
+	if opts.TLS == nil {
 		return nil
 	}
 
-	p := ensureCAForS3(&s.Spec.Template.Spec, tls)
+	p := ensureCAForObjectStorage(&s.Spec.Template.Spec, opts)
 
 	if err := mergo.Merge(&s.Spec.Template.Spec, p, mergo.WithOverride); err != nil {
-		return kverrors.Wrap(err, "failed to merge s3 object storage ca options ")
+		return kverrors.Wrap(err, "failed to merge object storage ca options ")
 	}
 
+	if s.Spec.Template.Annotations == nil {
+		s.Spec.Template.Annotations = map[string]string{}
+	}
+	s.Spec.Template.Annotations[caHashAnnotation] = CAHashAnnotationSource(opts.TLS)
+
 	return nil
 }
 
@@ -108,9 +114,10 @@ func ensureObjectStoreCredentials(p *corev1.PodSpec, opts Options) corev1.PodSpe
 	container := p.Containers[0].DeepCopy()
 	volumes := p.Volumes
 	secretName := opts.SecretName
+	volumeName := naming.DNSName(secretName)
 
 	volumes = append(volumes, corev1.Volume{
-		Name: secretName,
+		Name: volumeName,
 		VolumeSource: corev1.VolumeSource{
 			Secret: &corev1.SecretVolumeSource{
 				SecretName: secretName,
@@ -119,24 +126,16 @@ func ensureObjectStoreCredentials(p *corev1.PodSpec, opts Options) corev1.PodSpe
 	})
 
 	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
-		Name:      secretName,
+		Name:      volumeName,
 		ReadOnly:  false,
 		MountPath: secretDirectory,
 	})
 
-	if managedAuthEnabled(opts) {
-		container.Env = append(container.Env, managedAuthCredentials(opts)...)
-		volumes = append(volumes, saTokenVolume(opts))
-		container.VolumeMounts = append(container.VolumeMounts, saTokenVolumeMount(opts))
-
-		if opts.OpenShift.ManagedAuthEnabled() {
-			volumes = append(volumes, managedAuthVolume(opts))
-			container.VolumeMounts = append(container.VolumeMounts, managedAuthVolumeMount(opts))
-		}
-	} else {
-		container.Env = append(container.Env, staticAuthCredentials(opts)...)
-	}
-	container.Env = append(container.Env, serverSideEncryption(opts)...)
+	strategy := authStrategyFor(opts)
+	container.Env = append(container.Env, strategy.EnvVars(opts)...)
+	volumes = append(volumes, strategy.Volumes(opts)...)
+	container.VolumeMounts = append(container.VolumeMounts, strategy.VolumeMounts(opts)...)
+	container.Args = append(container.Args, strategy.Args(opts)...)
 
 	return corev1.PodSpec{
 		Containers: []corev1.Container{
@@ -164,10 +163,14 @@ func staticAuthCredentials(opts Options) []corev1.EnvVar {
 			envVarFromValue(EnvGoogleApplicationCredentials, path.Join(secretDirectory, KeyGCPServiceAccountKeyFilename)),
 		}
 	case lokiv1.ObjectStorageSecretS3:
-		return []corev1.EnvVar{
+		vars := []corev1.EnvVar{
 			envVarFromSecret(EnvAWSAccessKeyID, secretName, KeyAWSAccessKeyID),
 			envVarFromSecret(EnvAWSAccessKeySecret, secretName, KeyAWSAccessKeySecret),
 		}
+		if opts.S3 != nil && opts.S3.SessionToken {
+			vars = append(vars, envVarFromSecret(EnvAWSSessionToken, secretName, KeyAWSSessionToken))
+		}
+		return vars
 	case lokiv1.ObjectStorageSecretSwift:
 		return []corev1.EnvVar{
 			envVarFromSecret(EnvSwiftUsername, secretName, KeySwiftUsername),
@@ -187,10 +190,23 @@ func managedAuthCredentials(opts Options) []corev1.EnvVar {
 				envVarFromValue(EnvAWSSdkLoadConfig, "true"),
 			}
 		} else {
-			return []corev1.EnvVar{
+			vars := []corev1.EnvVar{
 				envVarFromSecret(EnvAWSRoleArn, opts.SecretName, KeyAWSRoleArn),
 				envVarFromValue(EnvAWSWebIdentityTokenFile, path.Join(AWSTokenVolumeDirectory, "token")),
 			}
+			if opts.S3.RoleSessionName != "" {
+				vars = append(vars, envVarFromValue(EnvAWSRoleSessionName, opts.S3.RoleSessionName))
+			}
+			if opts.S3.SourceRoleArn != "" {
+				// The chained source role is assumed first via the AWS SDK's
+				// role-chaining convention, with RoleArn's profile naming the
+				// source role's profile as its source_profile. These profiles live in a synthesized
+				// AWS_CONFIG_FILE mounted from RoleChainConfigMapName, the
+				// same way the GCS external_account file is mounted for
+				// Workload Identity Federation.
+				vars = append(vars, envVarFromValue(EnvAWSConfigFile, path.Join(awsRoleChainConfigDirectory, KeyAWSRoleChainConfigFilename)))
+			}
+			return vars
 		}
 	case lokiv1.ObjectStorageSecretAzure:
 		if opts.OpenShift.ManagedAuthEnabled() {
@@ -210,11 +226,114 @@ func managedAuthCredentials(opts Options) []corev1.EnvVar {
 			envVarFromSecret(EnvAzureSubscriptionID, opts.SecretName, KeyAzureStorageSubscriptionID),
 			envVarFromValue(EnvAzureFederatedTokenFile, path.Join(azureTokenVolumeDirectory, "token")),
 		}
+	case lokiv1.ObjectStorageSecretGCS:
+		return []corev1.EnvVar{
+			envVarFromValue(EnvGoogleApplicationCredentials, path.Join(gcsWorkloadIdentityConfigDirectory, KeyGCSWorkloadIdentityCredentialsFilename)),
+		}
 	default:
 		return []corev1.EnvVar{}
 	}
 }
 
+func gcsWorkloadIdentityConfigVolumeSource(opts Options) corev1.Volume {
+	return corev1.Volume{
+		Name: gcsWorkloadIdentityConfigVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: opts.GCS.WorkloadIdentityConfigMapName,
+				},
+			},
+		},
+	}
+}
+
+func gcsWorkloadIdentityConfigVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      gcsWorkloadIdentityConfigVolume,
+		ReadOnly:  true,
+		MountPath: gcsWorkloadIdentityConfigDirectory,
+	}
+}
+
+func awsRoleChainConfigVolumeSource(opts Options) corev1.Volume {
+	return corev1.Volume{
+		Name: awsRoleChainConfigVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: opts.S3.RoleChainConfigMapName,
+				},
+			},
+		},
+	}
+}
+
+func awsRoleChainConfigVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      awsRoleChainConfigVolume,
+		ReadOnly:  true,
+		MountPath: awsRoleChainConfigDirectory,
+	}
+}
+
+// ValidateAWSRoleChain ensures a source role ARN used for AWS STS role
+// chaining is backed by a synthesized AWS_CONFIG_FILE ConfigMap. Without one
+// the AWS SDK silently fails to find the chained profile at runtime instead
+// of surfacing a clear configuration error.
+func ValidateAWSRoleChain(sourceRoleArn, roleChainConfigMapName string) error {
+	if sourceRoleArn != "" && roleChainConfigMapName == "" {
+		return kverrors.New("aws role chaining requires a source role config to be synthesized when source_role_arn is set")
+	}
+
+	return nil
+}
+
+// ValidateGCSWorkloadIdentity rejects mixing a static service account key
+// with GCS Workload Identity Federation on the same object storage secret,
+// since the two auth modes are mutually exclusive, and rejects enabling
+// Workload Identity Federation without an explicit audience: GCP WIF
+// audiences are project/pool/provider-specific, so there is no generic
+// default to silently fall back to.
+func ValidateGCSWorkloadIdentity(workloadIdentity bool, hasServiceAccountKey bool, audience string) error {
+	if workloadIdentity && hasServiceAccountKey {
+		return kverrors.New("cannot enable GCS workload identity federation and a static service account key on the same secret")
+	}
+	if workloadIdentity && audience == "" {
+		return kverrors.New("gcs workload identity federation requires an explicit audience")
+	}
+
+	return nil
+}
+
+func azureServicePrincipalEnabled(opts Options) bool {
+	return opts.SharedStore == lokiv1.ObjectStorageSecretAzure &&
+		opts.Azure != nil && opts.Azure.CredentialMode == lokiv1.CredentialModeAzureServicePrincipal
+}
+
+func azureServicePrincipalCredentials(opts Options) []corev1.EnvVar {
+	secretName := opts.SecretName
+	return []corev1.EnvVar{
+		envVarFromSecret(EnvAzureStorageAccountName, secretName, KeyAzureStorageAccountName),
+		envVarFromSecret(EnvAzureClientID, secretName, KeyAzureStorageClientID),
+		envVarFromSecret(EnvAzureClientSecret, secretName, KeyAzureStorageClientSecret),
+		envVarFromSecret(EnvAzureTenantID, secretName, KeyAzureStorageTenantID),
+		envVarFromOptionalSecret(EnvAzureSubscriptionID, secretName, KeyAzureStorageSubscriptionID),
+	}
+}
+
+// ValidateAzureServicePrincipal ensures the service principal fields read from
+// an Azure object storage secret are complete. A client ID without both a
+// client secret and tenant ID cannot authenticate and is rejected early,
+// rather than surfacing as an opaque auth failure from the Azure SDK.
+func ValidateAzureServicePrincipal(clientID, clientSecret, tenantID string) error {
+	if clientID != "" && (clientSecret == "" || tenantID == "") {
+		return kverrors.New("azure service principal requires client_secret and tenant_id when client_id is set")
+	}
+
+	return nil
+}
+
 func serverSideEncryption(opts Options) []corev1.EnvVar {
 	secretName := opts.SecretName
 	switch opts.SharedStore {
@@ -230,7 +349,8 @@ func serverSideEncryption(opts Options) []corev1.EnvVar {
 	}
 }
 
-func ensureCAForS3(p *corev1.PodSpec, tls *TLSConfig) corev1.PodSpec {
+func ensureCAForObjectStorage(p *corev1.PodSpec, opts Options) corev1.PodSpec {
+	tls := opts.TLS
 	container := p.Containers[0].DeepCopy()
 	volumes := p.Volumes
 
@@ -252,9 +372,36 @@ func ensureCAForS3(p *corev1.PodSpec, tls *TLSConfig) corev1.PodSpec {
 	})
 
 	container.Args = append(container.Args,
-		fmt.Sprintf("-s3.http.ca-file=%s", path.Join(caDirectory, tls.Key)),
+		fmt.Sprintf("%s=%s", caFileFlag(opts.SharedStore), path.Join(caDirectory, tls.Key)),
 	)
 
+	if tls.SystemCABundle {
+		// Mounted as its own whole-directory volume, remapped to the
+		// well-known filename via Items, rather than a subPath mount of
+		// storageTLSVolume: kubelet does not propagate ConfigMap updates to
+		// subPath mounts, which would leave this copy stale until the pod
+		// happened to restart for an unrelated reason.
+		volumes = append(volumes, corev1.Volume{
+			Name: systemCABundleVolume,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: tls.CA,
+					},
+					Items: []corev1.KeyToPath{
+						{Key: tls.Key, Path: path.Base(systemCABundleFile)},
+					},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      systemCABundleVolume,
+			ReadOnly:  true,
+			MountPath: path.Dir(systemCABundleFile),
+		})
+		container.Env = append(container.Env, envVarFromValue(EnvSSLCertFile, systemCABundleFile))
+	}
+
 	return corev1.PodSpec{
 		Containers: []corev1.Container{
 			*container,
@@ -263,6 +410,35 @@ func ensureCAForS3(p *corev1.PodSpec, tls *TLSConfig) corev1.PodSpec {
 	}
 }
 
+// caFileFlag returns the Loki CLI flag used to configure the custom CA bundle
+// for the given object storage backend.
+func caFileFlag(storeType lokiv1.ObjectStorageSecretType) string {
+	switch storeType {
+	case lokiv1.ObjectStorageSecretAlibabaCloud:
+		return "-s3.http.ca-file"
+	case lokiv1.ObjectStorageSecretAzure:
+		return "-azure.http.ca-file"
+	case lokiv1.ObjectStorageSecretGCS:
+		return "-gcs.http.ca-file"
+	case lokiv1.ObjectStorageSecretSwift:
+		return "-swift.http.ca-file"
+	default:
+		return "-s3.http.ca-file"
+	}
+}
+
+// CAHashAnnotationSource returns the value to feed into the pod template's
+// config hash annotation so that rotating the custom CA ConfigMap (renaming it
+// or changing the key it is stored under) triggers a rollout, the same way a
+// changed object storage secret does.
+func CAHashAnnotationSource(tls *TLSConfig) string {
+	if tls == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s/%s", tls.CA, tls.Key)
+}
+
 func envVarFromSecret(name, secretName, secretKey string) corev1.EnvVar {
 	return corev1.EnvVar{
 		Name: name,
@@ -284,12 +460,27 @@ func envVarFromValue(name, value string) corev1.EnvVar {
 	}
 }
 
-func managedAuthEnabled(opts Options) bool {
+// envVarFromOptionalSecret behaves like envVarFromSecret, but marks the key
+// as optional so a secret that omits it doesn't fail pod startup with
+// CreateContainerConfigError.
+func envVarFromOptionalSecret(name, secretName, secretKey string) corev1.EnvVar {
+	v := envVarFromSecret(name, secretName, secretKey)
+	v.ValueFrom.SecretKeyRef.Optional = ptr.To(true)
+	return v
+}
+
+// legacyManagedAuthEnabled reports whether opts carries one of the
+// pre-CredentialMode per-backend flags that implied a token-exchange auth
+// mode. Only consulted by effectiveCredentialMode as a fallback when
+// opts.CredentialMode is unset.
+func legacyManagedAuthEnabled(opts Options) bool {
 	switch opts.SharedStore {
 	case lokiv1.ObjectStorageSecretS3:
 		return opts.S3 != nil && opts.S3.STS
 	case lokiv1.ObjectStorageSecretAzure:
 		return opts.Azure != nil && opts.Azure.WorkloadIdentity
+	case lokiv1.ObjectStorageSecretGCS:
+		return opts.GCS != nil && opts.GCS.WorkloadIdentity
 	default:
 		return false
 	}
@@ -302,6 +493,8 @@ func saTokenVolumeMount(opts Options) corev1.VolumeMount {
 		tokenPath = AWSTokenVolumeDirectory
 	case lokiv1.ObjectStorageSecretAzure:
 		tokenPath = azureTokenVolumeDirectory
+	case lokiv1.ObjectStorageSecretGCS:
+		tokenPath = gcsTokenVolumeDirectory
 	}
 	return corev1.VolumeMount{
 		Name:      saTokenVolumeName,
@@ -323,6 +516,12 @@ func saTokenVolume(opts Options) corev1.Volume {
 		if opts.Azure.Audience != "" {
 			audience = opts.Azure.Audience
 		}
+	case lokiv1.ObjectStorageSecretGCS:
+		// GCP Workload Identity Federation audiences are
+		// project/pool/provider-specific: there is no usable default, so
+		// opts.GCS.Audience is used as is. ValidateGCSWorkloadIdentity rejects
+		// an empty audience; it is not called here.
+		audience = opts.GCS.Audience
 	}
 	return corev1.Volume{
 		Name: saTokenVolumeName,
@@ -344,14 +543,14 @@ func saTokenVolume(opts Options) corev1.Volume {
 
 func managedAuthVolumeMount(opts Options) corev1.VolumeMount {
 	return corev1.VolumeMount{
-		Name:      opts.OpenShift.CloudCredentials.SecretName,
+		Name:      naming.DNSName(opts.OpenShift.CloudCredentials.SecretName),
 		MountPath: managedAuthSecretDirectory,
 	}
 }
 
 func managedAuthVolume(opts Options) corev1.Volume {
 	return corev1.Volume{
-		Name: opts.OpenShift.CloudCredentials.SecretName,
+		Name: naming.DNSName(opts.OpenShift.CloudCredentials.SecretName),
 		VolumeSource: corev1.VolumeSource{
 			Secret: &corev1.SecretVolumeSource{
 				SecretName: opts.OpenShift.CloudCredentials.SecretName,
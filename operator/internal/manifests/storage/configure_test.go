@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+)
+
+func TestStaticAuthCredentials_S3_StaticKeysOnly(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretS3,
+		SecretName:  "test",
+		S3:          &S3StorageConfig{},
+	}
+
+	vars := staticAuthCredentials(opts)
+
+	require.Len(t, vars, 2)
+	require.Equal(t, EnvAWSAccessKeyID, vars[0].Name)
+	require.Equal(t, EnvAWSAccessKeySecret, vars[1].Name)
+}
+
+func TestStaticAuthCredentials_S3_StaticKeysWithSessionToken(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretS3,
+		SecretName:  "test",
+		S3:          &S3StorageConfig{SessionToken: true},
+	}
+
+	vars := staticAuthCredentials(opts)
+
+	require.Len(t, vars, 3)
+	require.Equal(t, EnvAWSAccessKeyID, vars[0].Name)
+	require.Equal(t, EnvAWSAccessKeySecret, vars[1].Name)
+	require.Equal(t, EnvAWSSessionToken, vars[2].Name)
+	require.Equal(t, KeyAWSSessionToken, vars[2].ValueFrom.SecretKeyRef.Key)
+}
+
+func TestEffectiveCredentialMode_GCS_WorkloadIdentity(t *testing.T) {
+	require.Equal(t, lokiv1.CredentialModeToken, effectiveCredentialMode(Options{
+		SharedStore: lokiv1.ObjectStorageSecretGCS,
+		GCS:         &GCSStorageConfig{WorkloadIdentity: true},
+	}))
+	require.Equal(t, lokiv1.CredentialModeStatic, effectiveCredentialMode(Options{
+		SharedStore: lokiv1.ObjectStorageSecretGCS,
+		GCS:         &GCSStorageConfig{},
+	}))
+}
+
+func TestEffectiveCredentialMode_ExplicitModeTakesPrecedence(t *testing.T) {
+	require.Equal(t, lokiv1.CredentialModeAzureServicePrincipal, effectiveCredentialMode(Options{
+		SharedStore:    lokiv1.ObjectStorageSecretAzure,
+		CredentialMode: lokiv1.CredentialModeAzureServicePrincipal,
+	}))
+}
+
+func TestAzureServicePrincipalCredentials_ProjectsAllFields(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretAzure,
+		SecretName:  "test",
+		Azure:       &AzureStorageConfig{CredentialMode: lokiv1.CredentialModeAzureServicePrincipal},
+	}
+
+	vars := azureServicePrincipalCredentials(opts)
+
+	require.Len(t, vars, 5)
+	require.Equal(t, EnvAzureStorageAccountName, vars[0].Name)
+	require.Equal(t, EnvAzureClientID, vars[1].Name)
+	require.Equal(t, EnvAzureClientSecret, vars[2].Name)
+	require.Equal(t, EnvAzureTenantID, vars[3].Name)
+	require.Equal(t, EnvAzureSubscriptionID, vars[4].Name)
+}
+
+func TestAzureServicePrincipalCredentials_SubscriptionIDOptional(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretAzure,
+		SecretName:  "test",
+		Azure:       &AzureStorageConfig{CredentialMode: lokiv1.CredentialModeAzureServicePrincipal},
+	}
+
+	vars := azureServicePrincipalCredentials(opts)
+
+	subscriptionID := vars[4]
+	require.Equal(t, EnvAzureSubscriptionID, subscriptionID.Name)
+	require.NotNil(t, subscriptionID.ValueFrom.SecretKeyRef.Optional)
+	require.True(t, *subscriptionID.ValueFrom.SecretKeyRef.Optional)
+}
+
+func TestValidateAzureServicePrincipal(t *testing.T) {
+	require.NoError(t, ValidateAzureServicePrincipal("", "", ""))
+	require.NoError(t, ValidateAzureServicePrincipal("client-id", "client-secret", "tenant-id"))
+	require.Error(t, ValidateAzureServicePrincipal("client-id", "", "tenant-id"))
+	require.Error(t, ValidateAzureServicePrincipal("client-id", "client-secret", ""))
+}
+
+func TestServicePrincipalAuthStrategy_NoSATokenVolume(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretAzure,
+		SecretName:  "test",
+		Azure:       &AzureStorageConfig{CredentialMode: lokiv1.CredentialModeAzureServicePrincipal},
+	}
+
+	strategy := authStrategyFor(opts)
+
+	require.IsType(t, servicePrincipalAuthStrategy{}, strategy)
+	require.Empty(t, strategy.Volumes(opts))
+	require.Empty(t, strategy.VolumeMounts(opts))
+
+	vars := strategy.EnvVars(opts)
+	require.Len(t, vars, 5)
+	require.Equal(t, EnvAzureStorageAccountName, vars[0].Name)
+}
+
+func TestRedactUnredactSecrets_RoundTrips(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretS3,
+		SecretName:  "my-lokistack-s3-secret",
+	}
+
+	redacted := opts.RedactSecrets()
+	require.Equal(t, redactedSecretValue, redacted.SecretName)
+
+	unredacted := redacted.UnredactSecrets(&opts)
+	require.Equal(t, opts.SecretName, unredacted.SecretName)
+}
+
+func TestRedactUnredactSecrets_S3RoleChainConfigMapRoundTrips(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretS3,
+		SecretName:  "my-lokistack-s3-secret",
+		S3:          &S3StorageConfig{RoleChainConfigMapName: "lokistack-aws-role-chain"},
+	}
+
+	redacted := opts.RedactSecrets()
+	require.Equal(t, redactedSecretValue, redacted.S3.RoleChainConfigMapName)
+
+	unredacted := redacted.UnredactSecrets(&opts)
+	require.Equal(t, opts.S3.RoleChainConfigMapName, unredacted.S3.RoleChainConfigMapName)
+}
+
+func TestValidateGCSWorkloadIdentity_RejectsMixedModes(t *testing.T) {
+	require.NoError(t, ValidateGCSWorkloadIdentity(true, false, "https://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider"))
+	require.NoError(t, ValidateGCSWorkloadIdentity(false, true, ""))
+	require.Error(t, ValidateGCSWorkloadIdentity(true, true, "https://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider"))
+}
+
+func TestValidateGCSWorkloadIdentity_RejectsMissingAudience(t *testing.T) {
+	require.Error(t, ValidateGCSWorkloadIdentity(true, false, ""))
+}
+
+func TestManagedAuthCredentials_S3_RoleChaining(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretS3,
+		SecretName:  "test",
+		S3: &S3StorageConfig{
+			RoleSessionName:        "lokistack-session",
+			SourceRoleArn:          "arn:aws:iam::123456789012:role/source-role",
+			RoleChainConfigMapName: "lokistack-aws-role-chain",
+		},
+	}
+
+	vars := managedAuthCredentials(opts)
+
+	require.Len(t, vars, 4)
+	require.Equal(t, EnvAWSRoleArn, vars[0].Name)
+	require.Equal(t, EnvAWSWebIdentityTokenFile, vars[1].Name)
+	require.Equal(t, EnvAWSRoleSessionName, vars[2].Name)
+	require.Equal(t, "lokistack-session", vars[2].Value)
+	require.Equal(t, EnvAWSConfigFile, vars[3].Name)
+
+	volumes := tokenAuthStrategy{}.Volumes(opts)
+	require.Len(t, volumes, 2)
+	require.Equal(t, awsRoleChainConfigVolume, volumes[1].Name)
+	require.Equal(t, "lokistack-aws-role-chain", volumes[1].ConfigMap.Name)
+
+	mounts := tokenAuthStrategy{}.VolumeMounts(opts)
+	require.Len(t, mounts, 2)
+	require.Equal(t, awsRoleChainConfigVolume, mounts[1].Name)
+}
+
+func TestCAFileFlag_DispatchesPerBackend(t *testing.T) {
+	require.Equal(t, "-s3.http.ca-file", caFileFlag(lokiv1.ObjectStorageSecretAlibabaCloud))
+	require.Equal(t, "-s3.http.ca-file", caFileFlag(lokiv1.ObjectStorageSecretS3))
+	require.Equal(t, "-azure.http.ca-file", caFileFlag(lokiv1.ObjectStorageSecretAzure))
+	require.Equal(t, "-gcs.http.ca-file", caFileFlag(lokiv1.ObjectStorageSecretGCS))
+	require.Equal(t, "-swift.http.ca-file", caFileFlag(lokiv1.ObjectStorageSecretSwift))
+}
+
+func TestCAHashAnnotationSource(t *testing.T) {
+	require.Equal(t, "", CAHashAnnotationSource(nil))
+	require.Equal(t, "my-ca-configmap/service-ca.crt", CAHashAnnotationSource(&TLSConfig{CA: "my-ca-configmap", Key: "service-ca.crt"}))
+}
+
+func TestEnsureCAForObjectStorage_SystemCABundle(t *testing.T) {
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretGCS,
+		TLS: &TLSConfig{
+			CA:             "my-ca-configmap",
+			Key:            "service-ca.crt",
+			SystemCABundle: true,
+		},
+	}
+	p := &corev1.PodSpec{Containers: []corev1.Container{{}}}
+
+	out := ensureCAForObjectStorage(p, opts)
+
+	require.Len(t, out.Containers[0].VolumeMounts, 2)
+	require.Equal(t, systemCABundleVolume, out.Containers[0].VolumeMounts[1].Name)
+	require.Equal(t, "", out.Containers[0].VolumeMounts[1].SubPath)
+	require.Contains(t, out.Containers[0].Args, "-gcs.http.ca-file="+caDirectory+"/service-ca.crt")
+	require.Equal(t, EnvSSLCertFile, out.Containers[0].Env[0].Name)
+
+	require.Len(t, out.Volumes, 2)
+	require.Equal(t, systemCABundleVolume, out.Volumes[1].Name)
+	require.Equal(t, []corev1.KeyToPath{{Key: "service-ca.crt", Path: "ca-bundle.crt"}}, out.Volumes[1].ConfigMap.Items)
+}
+
+func TestConfigureDeploymentCA_SetsHashAnnotation(t *testing.T) {
+	d := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{}}},
+			},
+		},
+	}
+	opts := Options{
+		SharedStore: lokiv1.ObjectStorageSecretS3,
+		TLS:         &TLSConfig{CA: "my-ca-configmap", Key: "service-ca.crt"},
+	}
+
+	require.NoError(t, configureDeploymentCA(d, opts))
+
+	require.Equal(t, "my-ca-configmap/service-ca.crt", d.Spec.Template.Annotations[caHashAnnotation])
+}
+
+func TestValidateAWSRoleChain(t *testing.T) {
+	require.NoError(t, ValidateAWSRoleChain("", ""))
+	require.NoError(t, ValidateAWSRoleChain("arn:aws:iam::123456789012:role/source-role", "lokistack-aws-role-chain"))
+	require.Error(t, ValidateAWSRoleChain("arn:aws:iam::123456789012:role/source-role", ""))
+}
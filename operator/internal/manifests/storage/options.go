@@ -0,0 +1,231 @@
+package storage
+
+import (
+	lokiv1 "github.com/grafana/loki/operator/apis/loki/v1"
+)
+
+// Options is used to configure Loki to authenticate with the object storage
+// provider selected by the lokistack spec. It is passed down to the
+// per-component manifest builders so they can project the required
+// environment variables, volumes and container args.
+type Options struct {
+	SharedStore lokiv1.ObjectStorageSecretType
+
+	// CredentialMode selects the AuthStrategy used to authenticate with the
+	// object storage backend. When empty, it is resolved from the legacy
+	// per-backend flags (S3.STS, Azure.WorkloadIdentity, GCS.WorkloadIdentity,
+	// Azure.CredentialMode) for callers that have not yet been migrated to set
+	// it explicitly, and is lokiv1.CredentialModeStatic if none of them is set.
+	CredentialMode lokiv1.CredentialMode
+
+	SecretName string
+	TLS        *TLSConfig
+
+	AlibabaCloud *AlibabaCloudStorageConfig
+	Azure        *AzureStorageConfig
+	GCS          *GCSStorageConfig
+	S3           *S3StorageConfig
+	Swift        *SwiftStorageConfig
+
+	OpenShift OpenShiftOptions
+}
+
+// TLSConfig is the configuration for the custom CA bundle used to talk to
+// the object storage endpoint.
+type TLSConfig struct {
+	CA  string
+	Key string
+
+	// SystemCABundle additionally mounts the CA bundle at the well-known
+	// system trust store location and sets SSL_CERT_FILE, so SDKs that link
+	// against legacy libssl (e.g. the Azure and GCS SDKs) pick it up without
+	// needing a dedicated CLI flag.
+	SystemCABundle bool
+}
+
+// AlibabaCloudStorageConfig for Alibaba Cloud OSS storage config.
+type AlibabaCloudStorageConfig struct {
+	Endpoint string
+	Bucket   string
+}
+
+// AzureStorageConfig for Azure storage config.
+type AzureStorageConfig struct {
+	Env              string
+	Container        string
+	EndpointSuffix   string
+	Audience         string
+	WorkloadIdentity bool
+
+	// CredentialMode selects how the Azure auth mode is surfaced to the object
+	// storage secret owner. Defaults to lokiv1.CredentialModeStatic when empty,
+	// which projects the storage account key. Set to
+	// lokiv1.CredentialModeAzureServicePrincipal to authenticate with a
+	// client ID/secret/tenant service principal instead.
+	CredentialMode lokiv1.CredentialMode
+}
+
+// GCSStorageConfig for GCS storage config.
+type GCSStorageConfig struct {
+	Bucket string
+
+	// WorkloadIdentity enables GCP Workload Identity Federation (GKE Workload
+	// Identity or direct workload identity federation): a projected service
+	// account token is exchanged for GCP credentials instead of mounting a
+	// static service account key from the object storage secret.
+	WorkloadIdentity bool
+	// Audience is the GCP Workload Identity Federation audience the projected
+	// service account token is issued for. GCP WIF audiences are
+	// project/pool/provider-specific, so there is no usable default: Audience
+	// is required whenever WorkloadIdentity is enabled and is rejected at
+	// validation time if left empty.
+	Audience string
+	// ServiceAccountEmail is the GCP service account impersonated through the
+	// external_account credential exchange.
+	ServiceAccountEmail string
+	// WorkloadIdentityConfigMapName names the ConfigMap holding the
+	// synthesized `external_account` credentials JSON that is mounted and
+	// referenced by GOOGLE_APPLICATION_CREDENTIALS.
+	WorkloadIdentityConfigMapName string
+}
+
+// S3StorageConfig for S3 storage config.
+type S3StorageConfig struct {
+	Endpoint string
+	Region   string
+	Buckets  string
+	Audience string
+	STS      bool
+	SSE      S3SSEConfig
+
+	// SessionToken marks that the object storage secret carries a short-lived
+	// AWS_SESSION_TOKEN alongside the static access key pair, e.g. credentials
+	// issued by AWS STS, Vault's AWS engine, or an external-secrets operator.
+	SessionToken bool
+
+	// RoleSessionName is an optional session name used when assuming
+	// RoleArn through AWS STS web identity federation.
+	RoleSessionName string
+	// SourceRoleArn optionally chains an intermediate role that must be
+	// assumed via AWS_CONFIG_FILE before assuming RoleArn, following the AWS
+	// SDK role-chaining convention.
+	SourceRoleArn string
+	// RoleChainConfigMapName names the ConfigMap holding the synthesized
+	// AWS_CONFIG_FILE profile that chains SourceRoleArn into RoleArn. Required
+	// when SourceRoleArn is set.
+	RoleChainConfigMapName string
+}
+
+// S3SSEConfig for S3 SSE config.
+type S3SSEConfig struct {
+	Type                 SSEType
+	KMSEncryptionContext string
+	KMSKeyID             string
+}
+
+// SwiftStorageConfig for Swift storage config.
+type SwiftStorageConfig struct {
+	AuthURL   string
+	Container string
+}
+
+// SSEType defines the server-side encryption type.
+type SSEType string
+
+const (
+	// SSEKMSType is the server-side encryption type for SSE-KMS.
+	SSEKMSType SSEType = "SSE-KMS"
+	// SSES3Type is the server-side encryption type for SSE-S3.
+	SSES3Type SSEType = "SSE-S3"
+)
+
+// OpenShiftOptions is used to configure the options specific to OpenShift.
+type OpenShiftOptions struct {
+	Enabled bool
+
+	// CloudCredentials holds the secret and configmap information propagated
+	// from a storage secret annotated by the OpenShift cloud-credential-operator.
+	CloudCredentials CloudCredentials
+}
+
+// CloudCredentials holds the secret name created by the OpenShift
+// cloud-credential-operator in response to a CredentialsRequest.
+type CloudCredentials struct {
+	SecretName string
+}
+
+// ManagedAuthEnabled returns true when the OpenShift cloud-credential-operator
+// has provisioned credentials for this storage secret.
+func (o OpenShiftOptions) ManagedAuthEnabled() bool {
+	return o.Enabled && o.CloudCredentials.SecretName != ""
+}
+
+// redactedSecretValue replaces any secret/credential reference RedactSecrets
+// strips out of an Options value before it is surfaced outside the cluster.
+const redactedSecretValue = "REDACTED"
+
+// RedactSecrets returns a copy of opts with every object storage secret or
+// credential reference replaced by redactedSecretValue, safe to surface in
+// LokiStack status or other observed-configuration reporting without leaking
+// which secrets and config sources back the stack.
+func (o Options) RedactSecrets() Options {
+	redacted := o
+
+	if redacted.SecretName != "" {
+		redacted.SecretName = redactedSecretValue
+	}
+	if redacted.OpenShift.CloudCredentials.SecretName != "" {
+		redacted.OpenShift.CloudCredentials.SecretName = redactedSecretValue
+	}
+	if redacted.GCS != nil && redacted.GCS.WorkloadIdentityConfigMapName != "" {
+		gcs := *redacted.GCS
+		gcs.WorkloadIdentityConfigMapName = redactedSecretValue
+		redacted.GCS = &gcs
+	}
+	if redacted.S3 != nil && redacted.S3.RoleChainConfigMapName != "" {
+		s3 := *redacted.S3
+		s3.RoleChainConfigMapName = redactedSecretValue
+		redacted.S3 = &s3
+	}
+
+	return redacted
+}
+
+// UnredactSecrets returns a copy of o with every field RedactSecrets replaced
+// re-hydrated from prev, so that an admin-edited redacted spec (e.g. read
+// back from status) can be round-tripped without having to resupply the
+// original secret and credential references.
+func (o Options) UnredactSecrets(prev *Options) Options {
+	unredacted := o
+
+	if prev == nil {
+		return unredacted
+	}
+
+	if unredacted.SecretName == redactedSecretValue {
+		unredacted.SecretName = prev.SecretName
+	}
+	if unredacted.OpenShift.CloudCredentials.SecretName == redactedSecretValue {
+		unredacted.OpenShift.CloudCredentials.SecretName = prev.OpenShift.CloudCredentials.SecretName
+	}
+	if unredacted.GCS != nil && unredacted.GCS.WorkloadIdentityConfigMapName == redactedSecretValue {
+		gcs := *unredacted.GCS
+		if prev.GCS != nil {
+			gcs.WorkloadIdentityConfigMapName = prev.GCS.WorkloadIdentityConfigMapName
+		} else {
+			gcs.WorkloadIdentityConfigMapName = ""
+		}
+		unredacted.GCS = &gcs
+	}
+	if unredacted.S3 != nil && unredacted.S3.RoleChainConfigMapName == redactedSecretValue {
+		s3 := *unredacted.S3
+		if prev.S3 != nil {
+			s3.RoleChainConfigMapName = prev.S3.RoleChainConfigMapName
+		} else {
+			s3.RoleChainConfigMapName = ""
+		}
+		unredacted.S3 = &s3
+	}
+
+	return unredacted
+}
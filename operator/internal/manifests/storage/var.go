@@ -0,0 +1,170 @@
+package storage
+
+const (
+	// secretDirectory is the path at which the object storage secret is mounted.
+	secretDirectory = "/etc/storage/secrets"
+
+	// caDirectory is the path at which a custom CA configmap is mounted.
+	caDirectory = "/etc/storage/ca"
+
+	// storageTLSVolume is the name of the volume used for the custom CA configmap.
+	storageTLSVolume = "cloud-storage-tls"
+
+	// systemCABundleVolume is the name of the volume that remaps the custom CA
+	// configmap's key to the well-known system trust store filename and is
+	// mounted as a whole directory (no subPath) at systemCABundleFile's parent,
+	// so ConfigMap updates propagate the same way the tls.CA mount does instead
+	// of being stuck until the next unrelated pod restart.
+	systemCABundleVolume = "cloud-storage-system-ca-bundle"
+
+	// caHashAnnotation is the pod template annotation carrying CAHashAnnotationSource,
+	// so that renaming the custom CA ConfigMap or rotating its key triggers a rollout.
+	caHashAnnotation = "loki.grafana.com/object-storage-ca-hash"
+
+	// systemCABundleFile is the well-known system trust store location that the
+	// custom CA bundle is additionally mounted to when TLSConfig.SystemCABundle
+	// is enabled, so SDKs that do not honor the per-backend CLI flag still trust it.
+	systemCABundleFile = "/etc/pki/tls/certs/ca-bundle.crt"
+
+	// managedAuthSecretDirectory is the path at which the OpenShift cloud credentials secret is mounted.
+	managedAuthSecretDirectory = "/etc/storage/managed-auth"
+
+	// saTokenVolumeName is the name of the volume used for the projected service account token.
+	saTokenVolumeName = "bound-sa-token"
+
+	// saTokenExpiration is the expiration in seconds for the projected service account token.
+	saTokenExpiration = int64(3600)
+
+	// AWSTokenVolumeDirectory is the path at which the projected service account token is mounted for AWS STS.
+	AWSTokenVolumeDirectory = "/var/run/secrets/sts.amazonaws.com/serviceaccount/"
+
+	// azureTokenVolumeDirectory is the path at which the projected service account token is mounted for Azure Workload Identity.
+	azureTokenVolumeDirectory = "/var/run/secrets/azure/serviceaccount/"
+
+	// awsDefaultAudience is the default audience used for the AWS STS projected service account token.
+	awsDefaultAudience = "sts.amazonaws.com"
+
+	// azureDefaultAudience is the default audience used for the Azure Workload Identity projected service account token.
+	azureDefaultAudience = "api://AzureADTokenExchange"
+
+	// gcsTokenVolumeDirectory is the path at which the projected service account token is mounted for GCS Workload Identity.
+	gcsTokenVolumeDirectory = "/var/run/secrets/gcs/serviceaccount/"
+
+	// gcsWorkloadIdentityConfigVolume is the name of the volume carrying the synthesized `external_account` credentials JSON.
+	gcsWorkloadIdentityConfigVolume = "gcs-wif-credentials"
+
+	// gcsWorkloadIdentityConfigDirectory is the path at which the synthesized `external_account` credentials JSON is mounted.
+	gcsWorkloadIdentityConfigDirectory = "/etc/storage/gcs-wif"
+
+	// KeyGCSWorkloadIdentityCredentialsFilename is the name of the synthesized `external_account` credentials JSON file.
+	KeyGCSWorkloadIdentityCredentialsFilename = "credentials.json"
+
+	// awsRoleChainConfigVolume is the name of the volume carrying the synthesized AWS_CONFIG_FILE that chains SourceRoleArn into RoleArn.
+	awsRoleChainConfigVolume = "aws-role-chain-config"
+
+	// awsRoleChainConfigDirectory is the path at which the synthesized AWS_CONFIG_FILE is mounted.
+	awsRoleChainConfigDirectory = "/etc/storage/aws-role-chain"
+
+	// KeyAWSRoleChainConfigFilename is the name of the synthesized AWS_CONFIG_FILE role-chaining config file.
+	KeyAWSRoleChainConfigFilename = "config"
+
+	// azureManagedCredentialKeyClientID is the key in the OpenShift cloud credentials secret holding the Azure client ID.
+	azureManagedCredentialKeyClientID = "azure_client_id"
+	// azureManagedCredentialKeyTenantID is the key in the OpenShift cloud credentials secret holding the Azure tenant ID.
+	azureManagedCredentialKeyTenantID = "azure_tenant_id"
+	// azureManagedCredentialKeySubscriptionID is the key in the OpenShift cloud credentials secret holding the Azure subscription ID.
+	azureManagedCredentialKeySubscriptionID = "azure_subscription_id"
+
+	// EnvAlibabaCloudAccessKeyID is the environment variable name for the Alibaba Cloud access key ID.
+	EnvAlibabaCloudAccessKeyID = "ALIBABA_CLOUD_ACCESS_KEY_ID"
+	// EnvAlibabaCloudAccessKeySecret is the environment variable name for the Alibaba Cloud access key secret.
+	EnvAlibabaCloudAccessKeySecret = "ALIBABA_CLOUD_ACCESS_KEY_SECRET"
+
+	// EnvAzureStorageAccountName is the environment variable name for the Azure storage account name.
+	EnvAzureStorageAccountName = "AZURE_STORAGE_ACCOUNT_NAME"
+	// EnvAzureStorageAccountKey is the environment variable name for the Azure storage account key.
+	EnvAzureStorageAccountKey = "AZURE_STORAGE_ACCOUNT_KEY"
+	// EnvAzureClientID is the environment variable name for the Azure client ID.
+	EnvAzureClientID = "AZURE_CLIENT_ID"
+	// EnvAzureTenantID is the environment variable name for the Azure tenant ID.
+	EnvAzureTenantID = "AZURE_TENANT_ID"
+	// EnvAzureSubscriptionID is the environment variable name for the Azure subscription ID.
+	EnvAzureSubscriptionID = "AZURE_SUBSCRIPTION_ID"
+	// EnvAzureClientSecret is the environment variable name for the Azure service principal client secret.
+	EnvAzureClientSecret = "AZURE_CLIENT_SECRET"
+	// EnvAzureFederatedTokenFile is the environment variable name for the Azure federated token file path.
+	EnvAzureFederatedTokenFile = "AZURE_FEDERATED_TOKEN_FILE"
+
+	// EnvGoogleApplicationCredentials is the environment variable name for the GCP application credentials file path.
+	EnvGoogleApplicationCredentials = "GOOGLE_APPLICATION_CREDENTIALS"
+
+	// EnvSSLCertFile is the environment variable name honored by libssl-linked SDKs
+	// (e.g. Azure, GCS) to locate the system CA bundle.
+	EnvSSLCertFile = "SSL_CERT_FILE"
+
+	// EnvAWSAccessKeyID is the environment variable name for the AWS access key ID.
+	EnvAWSAccessKeyID = "AWS_ACCESS_KEY_ID"
+	// EnvAWSAccessKeySecret is the environment variable name for the AWS secret access key.
+	EnvAWSAccessKeySecret = "AWS_SECRET_ACCESS_KEY"
+	// EnvAWSCredentialsFile is the environment variable name for the AWS shared credentials file path.
+	EnvAWSCredentialsFile = "AWS_SHARED_CREDENTIALS_FILE"
+	// EnvAWSSdkLoadConfig is the environment variable name that enables the AWS SDK config file loading.
+	EnvAWSSdkLoadConfig = "AWS_SDK_LOAD_CONFIG"
+	// EnvAWSConfigFile is the environment variable name for the AWS SDK shared config file path,
+	// used to express role-chaining via a source_profile.
+	EnvAWSConfigFile = "AWS_CONFIG_FILE"
+	// EnvAWSRoleArn is the environment variable name for the AWS role ARN assumed through web identity federation.
+	EnvAWSRoleArn = "AWS_ROLE_ARN"
+	// EnvAWSWebIdentityTokenFile is the environment variable name for the AWS web identity token file path.
+	EnvAWSWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	// EnvAWSSseKmsEncryptionContext is the environment variable name for the AWS SSE-KMS encryption context.
+	EnvAWSSseKmsEncryptionContext = "AWS_SSE_KMS_ENCRYPTION_CONTEXT"
+	// EnvAWSSessionToken is the environment variable name for a short-lived AWS session token.
+	EnvAWSSessionToken = "AWS_SESSION_TOKEN"
+	// EnvAWSRoleSessionName is the environment variable name for the AWS STS role session name.
+	EnvAWSRoleSessionName = "AWS_ROLE_SESSION_NAME"
+
+	// EnvSwiftUsername is the environment variable name for the OpenStack Swift username.
+	EnvSwiftUsername = "SWIFT_USERNAME"
+	// EnvSwiftPassword is the environment variable name for the OpenStack Swift password.
+	EnvSwiftPassword = "SWIFT_PASSWORD"
+
+	// KeyAlibabaCloudAccessKeyID is the object storage secret key holding the Alibaba Cloud access key ID.
+	KeyAlibabaCloudAccessKeyID = "access_key_id"
+	// KeyAlibabaCloudSecretAccessKey is the object storage secret key holding the Alibaba Cloud access key secret.
+	KeyAlibabaCloudSecretAccessKey = "secret_access_key"
+
+	// KeyAzureStorageAccountName is the object storage secret key holding the Azure storage account name.
+	KeyAzureStorageAccountName = "account_name"
+	// KeyAzureStorageAccountKey is the object storage secret key holding the Azure storage account key.
+	KeyAzureStorageAccountKey = "account_key"
+	// KeyAzureStorageClientID is the object storage secret key holding the Azure Workload Identity or service principal client ID.
+	KeyAzureStorageClientID = "client_id"
+	// KeyAzureStorageClientSecret is the object storage secret key holding the Azure service principal client secret.
+	KeyAzureStorageClientSecret = "client_secret"
+	// KeyAzureStorageTenantID is the object storage secret key holding the Azure Workload Identity or service principal tenant ID.
+	KeyAzureStorageTenantID = "tenant_id"
+	// KeyAzureStorageSubscriptionID is the object storage secret key holding the Azure Workload Identity or service principal subscription ID.
+	KeyAzureStorageSubscriptionID = "subscription_id"
+
+	// KeyGCPServiceAccountKeyFilename is the object storage secret key holding the GCS service account key file.
+	KeyGCPServiceAccountKeyFilename = "key.json"
+
+	// KeyAWSAccessKeyID is the object storage secret key holding the AWS access key ID.
+	KeyAWSAccessKeyID = "access_key_id"
+	// KeyAWSAccessKeySecret is the object storage secret key holding the AWS secret access key.
+	KeyAWSAccessKeySecret = "secret_access_key"
+	// KeyAWSRoleArn is the object storage secret key holding the AWS role ARN assumed through web identity federation.
+	KeyAWSRoleArn = "role_arn"
+	// KeyAWSCredentialsFilename is the name of the AWS shared credentials file projected from the OpenShift cloud credentials secret.
+	KeyAWSCredentialsFilename = "credentials"
+	// KeyAWSSseKmsEncryptionContext is the object storage secret key holding the AWS SSE-KMS encryption context.
+	KeyAWSSseKmsEncryptionContext = "sse_kms_encryption_context"
+	// KeyAWSSessionToken is the object storage secret key holding a short-lived AWS session token.
+	KeyAWSSessionToken = "session_token"
+
+	// KeySwiftUsername is the object storage secret key holding the OpenStack Swift username.
+	KeySwiftUsername = "username"
+	// KeySwiftPassword is the object storage secret key holding the OpenStack Swift password.
+	KeySwiftPassword = "password"
+)
@@ -0,0 +1,58 @@
+// Package naming provides helpers for deriving Kubernetes object names that
+// are guaranteed to satisfy the constraints of the resource they are used on.
+package naming
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// dns1123LabelMaxLength is the maximum length of a DNS-1123 label, as enforced
+// by the Kubernetes API for object and volume names.
+const dns1123LabelMaxLength = 63
+
+// hashSuffixLength is the number of hex characters of the stable hash appended
+// to a truncated name to keep it unique and deterministic across upgrades.
+const hashSuffixLength = 8
+
+// DNSName sanitizes name so it satisfies the DNS-1123 label rules required of
+// a corev1.Volume name: lowercase alphanumeric characters or '-', starting and
+// ending with an alphanumeric character, at most 63 characters long. Invalid
+// runes are replaced with '-' and, if the result is too long, it is truncated
+// and a short, stable hash of the original name is appended so unrelated
+// inputs don't collide and upgrades don't churn the generated name.
+func DNSName(name string) string {
+	lower := strings.ToLower(name)
+
+	var b strings.Builder
+	for _, r := range lower {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	sanitized := strings.Trim(b.String(), "-")
+
+	if sanitized == "" {
+		sanitized = "x"
+	}
+
+	if len(sanitized) <= dns1123LabelMaxLength {
+		return sanitized
+	}
+
+	suffix := hash(name)
+	maxPrefix := dns1123LabelMaxLength - hashSuffixLength - 1
+	prefix := strings.Trim(sanitized[:maxPrefix], "-")
+
+	return fmt.Sprintf("%s-%s", prefix, suffix)
+}
+
+func hash(name string) string {
+	sum := sha1.Sum([]byte(name)) //nolint:gosec
+	return hex.EncodeToString(sum[:])[:hashSuffixLength]
+}
@@ -0,0 +1,35 @@
+package naming
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSName_ShortValidName_Unchanged(t *testing.T) {
+	require.Equal(t, "my-secret", DNSName("my-secret"))
+}
+
+func TestDNSName_LowercasesAndReplacesInvalidRunes(t *testing.T) {
+	require.Equal(t, "my-secret-name", DNSName("My_Secret.Name"))
+}
+
+func TestDNSName_TruncatesLongNamesWithStableHash(t *testing.T) {
+	longName := "openshift-logging-loki-managed-credentials-that-is-way-too-long-for-a-label"
+
+	out := DNSName(longName)
+
+	require.LessOrEqual(t, len(out), dns1123LabelMaxLength)
+	require.True(t, strings.HasPrefix(out, "openshift-logging-loki-managed-credentials"))
+
+	// Hashing must be deterministic so upgrades don't churn the generated name.
+	require.Equal(t, out, DNSName(longName))
+}
+
+func TestDNSName_DifferentLongNamesDoNotCollide(t *testing.T) {
+	a := DNSName(strings.Repeat("a", 100))
+	b := DNSName(strings.Repeat("b", 100))
+
+	require.NotEqual(t, a, b)
+}